@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSink writes each record as a JSON line to a file, rotating it to a
+// timestamped sibling once it would exceed maxBytes.
+type FileSink struct {
+	mu          sync.Mutex
+	path        string
+	maxBytes    int64
+	file        *os.File
+	currentSize int64
+}
+
+// NewFileSink opens (creating if necessary) the file at path for appending.
+// A maxBytes of 0 disables rotation.
+func NewFileSink(path string, maxBytes int64) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("audit: open %s: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("audit: stat %s: %w", path, err)
+	}
+
+	return &FileSink{
+		path:        path,
+		maxBytes:    maxBytes,
+		file:        f,
+		currentSize: info.Size(),
+	}, nil
+}
+
+func (s *FileSink) Write(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("audit: marshal record: %w", err)
+	}
+	data = append(data, '\n')
+
+	if s.maxBytes > 0 && s.currentSize+int64(len(data)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(data)
+	s.currentSize += int64(n)
+	if err != nil {
+		return fmt.Errorf("audit: write record: %w", err)
+	}
+	return nil
+}
+
+// rotate renames the current file aside with a timestamp suffix and opens a
+// fresh one in its place. Callers must hold s.mu.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("audit: close %s for rotation: %w", s.path, err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", s.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(s.path, rotated); err != nil {
+		return fmt.Errorf("audit: rotate %s: %w", s.path, err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("audit: reopen %s after rotation: %w", s.path, err)
+	}
+	s.file = f
+	s.currentSize = 0
+	return nil
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSink_WriteAppendsJSONLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path, 0)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(context.Background(), Record{RequestID: "req-1", Event: "token.issued"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit file: %v", err)
+	}
+	if !strings.Contains(string(data), `"request_id":"req-1"`) {
+		t.Errorf("expected written record in file, got: %s", data)
+	}
+}
+
+func TestFileSink_RotatesWhenOverMaxBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path, 10)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := sink.Write(context.Background(), Record{RequestID: "req", Event: "token.issued"}); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated file")
+	}
+}
@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Record is one audited occurrence of a token issuance attempt.
+type Record struct {
+	RequestID      string    `json:"request_id"`
+	Event          string    `json:"event"`
+	Identity       string    `json:"identity"`
+	RoomName       string    `json:"room_name"`
+	IssuerAPIKeyID string    `json:"issuer_api_key_id"`
+	ClientIP       string    `json:"client_ip"`
+	Timestamp      time.Time `json:"timestamp"`
+	// Reason is populated for a denied request, explaining why it was rejected.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Sink persists audit records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
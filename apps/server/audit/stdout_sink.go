@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// StdoutSink writes each record as a single JSON line to an underlying
+// writer, stdout by default.
+type StdoutSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewStdoutSink returns a StdoutSink writing to os.Stdout.
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{out: os.Stdout}
+}
+
+func (s *StdoutSink) Write(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(s.out).Encode(record)
+}
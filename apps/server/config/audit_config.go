@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/babelgopher/server/audit"
+)
+
+// LoadAuditSink builds the audit sink named by AUDIT_SINK. "stdout" (the
+// default) writes JSON lines to stdout; "file" writes to AUDIT_FILE_PATH,
+// rotating once it would exceed AUDIT_FILE_MAX_BYTES (10MB by default).
+func LoadAuditSink() (audit.Sink, error) {
+	switch os.Getenv("AUDIT_SINK") {
+	case "", "stdout":
+		return audit.NewStdoutSink(), nil
+
+	case "file":
+		path := os.Getenv("AUDIT_FILE_PATH")
+		if path == "" {
+			return nil, fmt.Errorf("audit: AUDIT_SINK=file requires AUDIT_FILE_PATH")
+		}
+		maxBytes := int64(10 * 1024 * 1024)
+		if raw := os.Getenv("AUDIT_FILE_MAX_BYTES"); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("audit: invalid AUDIT_FILE_MAX_BYTES: %w", err)
+			}
+			maxBytes = parsed
+		}
+		return audit.NewFileSink(path, maxBytes)
+
+	default:
+		return nil, fmt.Errorf("audit: unknown AUDIT_SINK %q", os.Getenv("AUDIT_SINK"))
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+// AuthConfig holds the resolved auth backend chain and session-cookie
+// signing secret used to gate /auth-livekit-token.
+type AuthConfig struct {
+	Backends      livekit.BackendChain
+	SessionSecret string
+}
+
+// LoadAuthConfig builds the backend chain named by AUTH_BACKENDS (a
+// comma-separated list such as "htpasswd,oauth2"), reading each backend's
+// own env vars. An empty AUTH_BACKENDS disables authentication.
+func LoadAuthConfig() (AuthConfig, error) {
+	cfg := AuthConfig{SessionSecret: os.Getenv("SESSION_SECRET")}
+
+	names := os.Getenv("AUTH_BACKENDS")
+	if names == "" {
+		return cfg, nil
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		backend, err := buildAuthBackend(name)
+		if err != nil {
+			return AuthConfig{}, err
+		}
+		cfg.Backends = append(cfg.Backends, backend)
+	}
+
+	return cfg, nil
+}
+
+func buildAuthBackend(name string) (livekit.AuthBackend, error) {
+	switch name {
+	case "htpasswd":
+		path := os.Getenv("HTPASSWD_FILE")
+		if path == "" {
+			return nil, fmt.Errorf("AUTH_BACKENDS includes htpasswd but HTPASSWD_FILE is not set")
+		}
+		return livekit.NewHtpasswdBackend(path), nil
+
+	case "static":
+		path := os.Getenv("STATIC_USERS_FILE")
+		if path == "" {
+			return nil, fmt.Errorf("AUTH_BACKENDS includes static but STATIC_USERS_FILE is not set")
+		}
+		return livekit.NewStaticUserBackend(path)
+
+	case "oauth2":
+		oauthCfg := livekit.OAuth2Config{
+			TokenURL:     os.Getenv("OAUTH2_TOKEN_URL"),
+			UserInfoURL:  os.Getenv("OAUTH2_USERINFO_URL"),
+			ClientID:     os.Getenv("OAUTH2_CLIENT_ID"),
+			ClientSecret: os.Getenv("OAUTH2_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OAUTH2_REDIRECT_URL"),
+		}
+		if oauthCfg.TokenURL == "" || oauthCfg.UserInfoURL == "" {
+			return nil, fmt.Errorf("AUTH_BACKENDS includes oauth2 but OAUTH2_TOKEN_URL/OAUTH2_USERINFO_URL are not set")
+		}
+		return livekit.NewOAuth2Backend(oauthCfg), nil
+
+	case "bearer-jwt":
+		jwksURL := os.Getenv("BEARER_JWT_JWKS_URL")
+		if jwksURL == "" {
+			return nil, fmt.Errorf("AUTH_BACKENDS includes bearer-jwt but BEARER_JWT_JWKS_URL is not set")
+		}
+		return livekit.NewBearerJWTBackend(jwksURL, os.Getenv("BEARER_JWT_ISSUER")), nil
+
+	default:
+		return nil, fmt.Errorf("unknown auth backend %q", name)
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/babelgopher/server/middleware"
+)
+
+// LoadCORSOptions builds middleware.Options from ALLOWED_ORIGINS (a
+// comma-separated list of exact origins or wildcard-subdomain patterns like
+// "https://*.example.com"). An unset ALLOWED_ORIGINS allows any origin,
+// which keeps local development working.
+func LoadCORSOptions() middleware.Options {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+
+	return middleware.Options{
+		AllowedOrigins: origins,
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:         10 * time.Minute,
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+// LoadGroupGrantMapping reads a YAML (.yaml/.yml) or JSON file mapping
+// group/role names to VideoGrant overrides. An empty path returns a nil
+// mapping, so no identity gets permissions beyond the default join grant.
+func LoadGroupGrantMapping(path string) (livekit.GroupGrantMapping, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("group grant mapping: read %s: %w", path, err)
+	}
+
+	mapping := livekit.GroupGrantMapping{}
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		if err := yaml.Unmarshal(data, &mapping); err != nil {
+			return nil, fmt.Errorf("group grant mapping: parse %s: %w", path, err)
+		}
+	} else if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("group grant mapping: parse %s: %w", path, err)
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/babelgopher/server/mfa"
+)
+
+// LoadMFAGate builds the MFA gate from MFA_CONFIG_FILE or MFA_CONFIG_JSON
+// (a JSON array of mfa.UserMFAConfig). If neither is set, MFA enforcement
+// is disabled and ok is false.
+func LoadMFAGate() (gate *mfa.Gate, ok bool, err error) {
+	var store *mfa.Store
+
+	switch {
+	case os.Getenv("MFA_CONFIG_FILE") != "":
+		store, err = mfa.LoadStoreFromFile(os.Getenv("MFA_CONFIG_FILE"))
+	case os.Getenv("MFA_CONFIG_JSON") != "":
+		store, err = mfa.LoadStoreFromEnv(os.Getenv("MFA_CONFIG_JSON"))
+	default:
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("mfa: %w", err)
+	}
+
+	validators := map[string]mfa.Validator{
+		"totp":        mfa.NewTOTPValidator(),
+		"backup-code": mfa.NewBackupCodeValidator(store),
+	}
+	return mfa.NewGate(store, validators), true, nil
+}
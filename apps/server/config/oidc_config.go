@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/babelgopher/server/oidc"
+)
+
+// LoadOIDCConfig builds the OIDC handler config from env vars. ok is false
+// when OIDC_ISSUER_URL is unset, meaning single sign-on is disabled.
+func LoadOIDCConfig(sessionSecret string) (cfg oidc.Config, ok bool) {
+	issuer := os.Getenv("OIDC_ISSUER_URL")
+	if issuer == "" {
+		return oidc.Config{}, false
+	}
+
+	var scopes []string
+	if raw := os.Getenv("OIDC_SCOPES"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			scopes = append(scopes, strings.TrimSpace(s))
+		}
+	}
+
+	return oidc.Config{
+		IssuerURL:     issuer,
+		ClientID:      os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:   os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:        scopes,
+		GroupsClaim:   os.Getenv("OIDC_GROUPS_CLAIM"),
+		SessionSecret: sessionSecret,
+	}, true
+}
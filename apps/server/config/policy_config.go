@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/babelgopher/server/policy"
+)
+
+type policyRulesetFile struct {
+	Rules []policy.Rule `yaml:"rules"`
+}
+
+// LoadPolicyEngine builds the room policy engine from the YAML ruleset
+// named by POLICY_RULESET_FILE. If unset, the policy engine is disabled
+// and the simpler group-to-grant mapping from LoadGroupGrantMapping is
+// used instead.
+func LoadPolicyEngine() (*policy.Engine, bool, error) {
+	path := os.Getenv("POLICY_RULESET_FILE")
+	if path == "" {
+		return nil, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("policy: read %s: %w", path, err)
+	}
+
+	var file policyRulesetFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, false, fmt.Errorf("policy: parse %s: %w", path, err)
+	}
+
+	return policy.NewEngine(file.Rules), true, nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/babelgopher/server/webhook"
+)
+
+type webhookEndpointConfig struct {
+	URL            string   `json:"url"`
+	Secret         string   `json:"secret"`
+	TimeoutSeconds int      `json:"timeout_seconds"`
+	MaxRetries     int      `json:"max_retries"`
+	BackoffMillis  int      `json:"backoff_millis"`
+	Events         []string `json:"events"`
+}
+
+// LoadWebhookDispatcher builds a webhook dispatcher from the endpoints
+// listed in WEBHOOK_CONFIG_FILE (a JSON array). If unset, webhook delivery
+// is disabled.
+func LoadWebhookDispatcher() (*webhook.Dispatcher, error) {
+	path := os.Getenv("WEBHOOK_CONFIG_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: read %s: %w", path, err)
+	}
+
+	var raw []webhookEndpointConfig
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("webhook: parse %s: %w", path, err)
+	}
+
+	endpoints := make([]webhook.Endpoint, 0, len(raw))
+	for _, r := range raw {
+		events := make([]webhook.Event, 0, len(r.Events))
+		for _, e := range r.Events {
+			events = append(events, webhook.Event(e))
+		}
+		endpoints = append(endpoints, webhook.Endpoint{
+			URL:         r.URL,
+			Secret:      r.Secret,
+			Timeout:     time.Duration(r.TimeoutSeconds) * time.Second,
+			MaxRetries:  r.MaxRetries,
+			BackoffBase: time.Duration(r.BackoffMillis) * time.Millisecond,
+			Events:      events,
+		})
+	}
+
+	return webhook.NewDispatcher(endpoints, 4, 256), nil
+}
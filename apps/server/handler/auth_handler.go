@@ -2,15 +2,30 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/livekit/protocol/auth"
+
+	"github.com/babelgopher/server/audit"
 	"github.com/babelgopher/server/livekit"
+	"github.com/babelgopher/server/mfa"
+	"github.com/babelgopher/server/policy"
+	"github.com/babelgopher/server/webhook"
 )
 
-// AuthRequest represents the request body for /auth-livekit-token
+// AuthRequest represents the request body for /auth-livekit-token and
+// /auth-livekit-token/preview. The caller's identity is resolved by the
+// configured auth backend chain, not taken from the request body, so
+// clients cannot spoof user_identity. RequestedPermissions is optional; if
+// set and a policy engine is configured, it narrows the grant the matched
+// rule produces but can never broaden it.
 type AuthRequest struct {
-	UserIdentity string `json:"user_identity"`
-	RoomName     string `json:"room_name"`
+	RoomName             string            `json:"room_name"`
+	RequestedPermissions *policy.GrantSpec `json:"requested_permissions,omitempty"`
 }
 
 // AuthResponse represents the success response with token
@@ -23,27 +38,25 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// AuthLiveKitTokenHandler handles POST /auth-livekit-token requests
-// apiKey, apiSecret, frontendURL should be passed from main via closure or config struct
-func AuthLiveKitTokenHandler(apiKey, apiSecret, frontendURL string) http.HandlerFunc {
+// AuthLiveKitTokenHandler handles POST /auth-livekit-token requests. CORS is
+// applied by the caller via middleware.CORS, not here.
+// apiKey, apiSecret should be passed from main via closure or config struct.
+// backends is the chain consulted to authenticate the caller before a token is minted,
+// and sessionSecret verifies the session cookie it may accept instead of an Authorization
+// header (e.g. one minted by the oidc package). An empty chain disables authentication,
+// which keeps local development working. grantMapping scopes the resulting VideoGrant by
+// the authenticated identity's groups; a nil mapping mints a join-only grant. mfaGate, if
+// non-nil, is consulted after authentication and rejects identities enrolled in MFA who
+// didn't present valid credentials for every required method. auditSink and
+// webhookDispatcher, if non-nil, each receive a record of the outcome once the caller's
+// identity has been resolved (or attempted); apiKey doubles as the issuer API key ID
+// reported in those records, since it is not secret. policyEngine, if non-nil, takes
+// over grant computation from grantMapping, evaluating the declarative ruleset against
+// the room and caller's identity/groups.
+func AuthLiveKitTokenHandler(apiKey, apiSecret string, backends livekit.BackendChain, sessionSecret string, grantMapping livekit.GroupGrantMapping, mfaGate *mfa.Gate, auditSink audit.Sink, webhookDispatcher *webhook.Dispatcher, policyEngine *policy.Engine) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers for frontend access
-		// Use specific frontend origin instead of wildcard for security
-		origin := frontendURL
-		if origin == "" {
-			origin = "*" // Fallback to wildcard for local development only
-		}
-		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 		w.Header().Set("Content-Type", "application/json")
 
-		// Handle preflight OPTIONS request
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
 		// Only accept POST requests
 		if r.Method != "POST" {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -64,25 +77,57 @@ func AuthLiveKitTokenHandler(apiKey, apiSecret, frontendURL string) http.Handler
 		}
 
 		// Validate required fields
-		if req.UserIdentity == "" {
+		if req.RoomName == "" {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(ErrorResponse{
-				Error: "missing required field: user_identity",
+				Error: "missing required field: room_name",
 			})
 			return
 		}
 
-		if req.RoomName == "" {
-			w.WriteHeader(http.StatusBadRequest)
+		requestID, err := newRequestID()
+		if err != nil {
+			log.Printf("auth: %v", err)
+		}
+
+		// Resolve the caller's identity before minting anything
+		user, err := authenticateRequest(r, backends, sessionSecret)
+		if err != nil {
+			notify(r, auditSink, webhookDispatcher, requestID, apiKey, user.Identity, req.RoomName, nil, webhook.EventTokenDenied, "authentication required: "+err.Error())
+			w.WriteHeader(http.StatusUnauthorized)
 			json.NewEncoder(w).Encode(ErrorResponse{
-				Error: "missing required field: room_name",
+				Error: "authentication required: " + err.Error(),
+			})
+			return
+		}
+
+		// Enforce any MFA required for this identity before minting a token
+		if mfaGate != nil {
+			if err := mfaGate.Require(r, user.Identity); err != nil {
+				notify(r, auditSink, webhookDispatcher, requestID, apiKey, user.Identity, req.RoomName, nil, webhook.EventTokenDenied, "mfa required: "+err.Error())
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(ErrorResponse{
+					Error: "mfa required: " + err.Error(),
+				})
+				return
+			}
+		}
+
+		// Compute the grant for the authenticated identity, via the policy
+		// engine if configured, falling back to the group-to-grant mapping
+		grant, err := computeGrant(req.RoomName, user, grantMapping, policyEngine, req.RequestedPermissions)
+		if err != nil {
+			notify(r, auditSink, webhookDispatcher, requestID, apiKey, user.Identity, req.RoomName, nil, webhook.EventTokenDenied, err.Error())
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: err.Error(),
 			})
 			return
 		}
 
-		// Generate LiveKit token
-		token, err := livekit.GenerateToken(apiKey, apiSecret, req.RoomName, req.UserIdentity)
+		token, err := mintToken(apiKey, apiSecret, user.Identity, grant)
 		if err != nil {
+			notify(r, auditSink, webhookDispatcher, requestID, apiKey, user.Identity, req.RoomName, nil, webhook.EventTokenDenied, "failed to generate token: "+err.Error())
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(ErrorResponse{
 				Error: "failed to generate token: " + err.Error(),
@@ -90,6 +135,8 @@ func AuthLiveKitTokenHandler(apiKey, apiSecret, frontendURL string) http.Handler
 			return
 		}
 
+		notify(r, auditSink, webhookDispatcher, requestID, apiKey, user.Identity, req.RoomName, grant, webhook.EventTokenIssued, "")
+
 		// Return success response with token
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(AuthResponse{
@@ -97,3 +144,79 @@ func AuthLiveKitTokenHandler(apiKey, apiSecret, frontendURL string) http.Handler
 		})
 	}
 }
+
+// notify records the outcome of a token issuance attempt to both the audit
+// sink and the webhook dispatcher, if configured. Either may be nil.
+func notify(r *http.Request, sink audit.Sink, dispatcher *webhook.Dispatcher, requestID, apiKeyID, identity, roomName string, grant *auth.VideoGrant, event webhook.Event, reason string) {
+	if sink == nil && dispatcher == nil {
+		return
+	}
+
+	timestamp := time.Now()
+	ip := clientIP(r)
+
+	if sink != nil {
+		record := audit.Record{
+			RequestID:      requestID,
+			Event:          string(event),
+			Identity:       identity,
+			RoomName:       roomName,
+			IssuerAPIKeyID: apiKeyID,
+			ClientIP:       ip,
+			Timestamp:      timestamp,
+			Reason:         reason,
+		}
+		if err := sink.Write(r.Context(), record); err != nil {
+			log.Printf("audit: write record: %v", err)
+		}
+	}
+
+	if dispatcher != nil {
+		dispatcher.Deliver(webhook.Payload{
+			RequestID:      requestID,
+			Event:          event,
+			Identity:       identity,
+			RoomName:       roomName,
+			Permissions:    grant,
+			IssuerAPIKeyID: apiKeyID,
+			ClientIP:       ip,
+			Timestamp:      timestamp,
+			Reason:         reason,
+		})
+	}
+}
+
+// authenticateRequest resolves the caller's identity from a session cookie
+// or an Authorization header, running the latter through the configured
+// backend chain. A session cookie (e.g. one minted by the oidc package) is
+// always honored, even with no backends configured; an empty chain and no
+// cookie or header means auth is disabled.
+func authenticateRequest(r *http.Request, backends livekit.BackendChain, sessionSecret string) (livekit.UserInfo, error) {
+	if cookie, err := r.Cookie(livekit.SessionCookieName); err == nil {
+		return livekit.ParseSessionToken(sessionSecret, cookie.Value)
+	}
+
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		if len(backends) == 0 {
+			return livekit.UserInfo{Identity: "anonymous"}, nil
+		}
+		return livekit.UserInfo{}, errors.New("missing session cookie or Authorization header")
+	}
+
+	creds := livekit.Credentials{}
+	switch {
+	case strings.HasPrefix(authHeader, "Bearer "):
+		creds.Token = strings.TrimPrefix(authHeader, "Bearer ")
+	case strings.HasPrefix(authHeader, "Basic "):
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			return livekit.UserInfo{}, errors.New("malformed Basic Authorization header")
+		}
+		creds.Username, creds.Password = username, password
+	default:
+		return livekit.UserInfo{}, errors.New("unsupported Authorization scheme")
+	}
+
+	return backends.Authenticate(r.Context(), creds)
+}
@@ -2,58 +2,76 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/babelgopher/server/audit"
+	"github.com/babelgopher/server/livekit"
+	"github.com/babelgopher/server/mfa"
+	"github.com/babelgopher/server/policy"
 )
 
-func TestAuthLiveKitTokenHandler_Success(t *testing.T) {
-	// Create handler with test credentials
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+func TestAuthLiveKitTokenHandler_Success_NoBackendsConfigured(t *testing.T) {
+	// With no backend chain configured, auth is disabled and any caller
+	// is accepted as "anonymous" (local development mode).
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, nil)
 
-	// Create valid request
-	reqBody := AuthRequest{
-		UserIdentity: "test-user",
-		RoomName:     "test-room",
-	}
+	reqBody := AuthRequest{RoomName: "test-room"}
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	// Call handler
 	handler(w, req)
 
-	// Check status code
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
 
-	// Check response body
 	var resp AuthResponse
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
-
-	// Token should not be empty
 	if resp.Token == "" {
 		t.Error("expected non-empty token")
 	}
+}
 
-	// Check CORS headers
-	if w.Header().Get("Access-Control-Allow-Origin") != "*" {
-		t.Error("expected CORS header to be set")
+func TestAuthLiveKitTokenHandler_SessionCookie(t *testing.T) {
+	backends := livekit.BackendChain{}
+	sessionSecret := "test-session-secret"
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", backends, sessionSecret, nil, nil, nil, nil, nil)
+
+	token, err := livekit.NewSessionToken(sessionSecret, livekit.UserInfo{Identity: "alice"}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to mint session token: %v", err)
 	}
-}
 
-func TestAuthLiveKitTokenHandler_MissingUserIdentity(t *testing.T) {
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: livekit.SessionCookieName, Value: token})
+	w := httptest.NewRecorder()
 
-	// Request missing user_identity
-	reqBody := AuthRequest{
-		RoomName: "test-room",
+	handler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
+}
+
+func TestAuthLiveKitTokenHandler_MissingAuth(t *testing.T) {
+	// A non-empty backend chain requires the caller to present credentials.
+	backends := livekit.BackendChain{}
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", append(backends, stubBackend{}), "", nil, nil, nil, nil, nil)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -61,26 +79,42 @@ func TestAuthLiveKitTokenHandler_MissingUserIdentity(t *testing.T) {
 
 	handler(w, req)
 
-	// Should return 400 Bad Request
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
 	}
+}
 
-	// Check error message
-	var errResp ErrorResponse
-	json.NewDecoder(w.Body).Decode(&errResp)
-	if errResp.Error != "missing required field: user_identity" {
-		t.Errorf("unexpected error message: %s", errResp.Error)
+func TestAuthLiveKitTokenHandler_MFARequiredButMissing(t *testing.T) {
+	store := mfa.NewStore([]mfa.UserMFAConfig{{Identity: "alice", RequiredMethods: []string{"totp"}}})
+	gate := mfa.NewGate(store, map[string]mfa.Validator{"totp": mfa.NewTOTPValidator()})
+
+	sessionSecret := "test-session-secret"
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, sessionSecret, nil, gate, nil, nil, nil)
+
+	token, err := livekit.NewSessionToken(sessionSecret, livekit.UserInfo{Identity: "alice"}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to mint session token: %v", err)
+	}
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: livekit.SessionCookieName, Value: token})
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d: %s", w.Code, w.Body.String())
 	}
 }
 
 func TestAuthLiveKitTokenHandler_MissingRoomName(t *testing.T) {
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, nil)
 
 	// Request missing room_name
-	reqBody := AuthRequest{
-		UserIdentity: "test-user",
-	}
+	reqBody := AuthRequest{}
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -102,7 +136,7 @@ func TestAuthLiveKitTokenHandler_MissingRoomName(t *testing.T) {
 }
 
 func TestAuthLiveKitTokenHandler_InvalidJSON(t *testing.T) {
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, nil)
 
 	// Send invalid JSON
 	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader([]byte("{invalid json}")))
@@ -118,7 +152,7 @@ func TestAuthLiveKitTokenHandler_InvalidJSON(t *testing.T) {
 }
 
 func TestAuthLiveKitTokenHandler_MethodNotAllowed(t *testing.T) {
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, nil)
 
 	// Send GET request (should only accept POST)
 	req := httptest.NewRequest("GET", "/auth-livekit-token", nil)
@@ -133,21 +167,142 @@ func TestAuthLiveKitTokenHandler_MethodNotAllowed(t *testing.T) {
 }
 
 func TestAuthLiveKitTokenHandler_OptionsRequest(t *testing.T) {
-	handler := AuthLiveKitTokenHandler("test-key", "test-secret")
+	// CORS preflight is handled by middleware.CORS before a request reaches
+	// the handler (see middleware/cors_test.go), so the bare handler treats
+	// OPTIONS like any other non-POST method.
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, nil)
 
-	// Send OPTIONS request for CORS preflight
 	req := httptest.NewRequest("OPTIONS", "/auth-livekit-token", nil)
 	w := httptest.NewRecorder()
 
 	handler(w, req)
 
-	// Should return 200 OK for preflight
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 for OPTIONS reaching the bare handler, got %d", w.Code)
+	}
+}
+
+func TestAuthLiveKitTokenHandler_RecordsAuditEvents(t *testing.T) {
+	sink := &fakeSink{}
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, sink, nil, nil)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d", len(sink.records))
+	}
+	if sink.records[0].Event != "token.issued" {
+		t.Errorf("expected token.issued event, got %q", sink.records[0].Event)
+	}
+	if sink.records[0].RequestID == "" {
+		t.Error("expected a non-empty request id")
+	}
+}
+
+func TestAuthLiveKitTokenHandler_RecordsAuditEventOnDenial(t *testing.T) {
+	sink := &fakeSink{}
+	backends := livekit.BackendChain{stubBackend{}}
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", backends, "", nil, nil, sink, nil, nil)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d", len(sink.records))
+	}
+	if sink.records[0].Event != "token.denied" {
+		t.Errorf("expected token.denied event, got %q", sink.records[0].Event)
+	}
+}
+
+func TestAuthLiveKitTokenHandler_PolicyEngineGrantsMatchingRule(t *testing.T) {
+	engine := policy.NewEngine([]policy.Rule{
+		{RoomPattern: "*", Grant: policy.GrantSpec{CanPublish: boolPtr(true)}},
+	})
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, nil, nil, engine)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
 	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200 for OPTIONS, got %d", w.Code)
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthLiveKitTokenHandler_PolicyEngineDeniesWhenNoRuleMatches(t *testing.T) {
+	sink := &fakeSink{}
+	engine := policy.NewEngine([]policy.Rule{
+		{RoomPattern: "lecture-*", Grant: policy.GrantSpec{CanPublish: boolPtr(true)}},
+	})
+	handler := AuthLiveKitTokenHandler("test-key", "test-secret", nil, "", nil, nil, sink, nil, engine)
+
+	reqBody := AuthRequest{RoomName: "standup"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// Check CORS headers are present
-	if w.Header().Get("Access-Control-Allow-Origin") == "" {
-		t.Error("expected CORS headers on OPTIONS request")
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.records) != 1 || sink.records[0].Event != "token.denied" {
+		t.Fatalf("expected 1 token.denied audit record, got %+v", sink.records)
 	}
 }
+
+func boolPtr(v bool) *bool { return &v }
+
+// fakeSink records every Write call for assertions, instead of persisting anywhere.
+type fakeSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (s *fakeSink) Write(ctx context.Context, record audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+// stubBackend always rejects, used to exercise the "no credentials supplied" path.
+type stubBackend struct{}
+
+func (stubBackend) Name() string { return "stub" }
+
+func (stubBackend) Authenticate(ctx context.Context, creds livekit.Credentials) (livekit.UserInfo, error) {
+	return livekit.UserInfo{}, livekit.ErrInvalidCredentials
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/livekit/protocol/auth"
+
+	"github.com/babelgopher/server/livekit"
+	"github.com/babelgopher/server/mfa"
+	"github.com/babelgopher/server/policy"
+)
+
+// PreviewResponse is returned by /auth-livekit-token/preview: the grant the
+// caller would receive from a matching /auth-livekit-token call, without
+// minting a token.
+type PreviewResponse struct {
+	Permissions *auth.VideoGrant `json:"permissions"`
+}
+
+// AuthLiveKitTokenPreviewHandler handles POST /auth-livekit-token/preview
+// requests. CORS is applied by the caller via middleware.CORS, not here. It
+// authenticates and enforces MFA identically to AuthLiveKitTokenHandler,
+// then returns the computed grant without minting a JWT, so frontends can
+// render UI affordances (e.g. a disabled publish button) accurately before
+// a participant joins.
+func AuthLiveKitTokenPreviewHandler(backends livekit.BackendChain, sessionSecret string, grantMapping livekit.GroupGrantMapping, mfaGate *mfa.Gate, policyEngine *policy.Engine) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: "method not allowed, use POST",
+			})
+			return
+		}
+
+		var req AuthRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: "invalid JSON body: " + err.Error(),
+			})
+			return
+		}
+
+		if req.RoomName == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: "missing required field: room_name",
+			})
+			return
+		}
+
+		user, err := authenticateRequest(r, backends, sessionSecret)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: "authentication required: " + err.Error(),
+			})
+			return
+		}
+
+		if mfaGate != nil {
+			if err := mfaGate.Require(r, user.Identity); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(ErrorResponse{
+					Error: "mfa required: " + err.Error(),
+				})
+				return
+			}
+		}
+
+		grant, err := computeGrant(req.RoomName, user, grantMapping, policyEngine, req.RequestedPermissions)
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(PreviewResponse{
+			Permissions: grant,
+		})
+	}
+}
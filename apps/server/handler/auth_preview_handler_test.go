@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/babelgopher/server/livekit"
+	"github.com/babelgopher/server/mfa"
+	"github.com/babelgopher/server/policy"
+)
+
+func TestAuthLiveKitTokenPreviewHandler_Success_ComputesGrant(t *testing.T) {
+	handler := AuthLiveKitTokenPreviewHandler(nil, "", nil, nil, nil)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token/preview", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp PreviewResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Permissions == nil || !resp.Permissions.RoomJoin || resp.Permissions.Room != "test-room" {
+		t.Errorf("expected a join-only grant for test-room, got %+v", resp.Permissions)
+	}
+}
+
+func TestAuthLiveKitTokenPreviewHandler_AuthRequired(t *testing.T) {
+	backends := livekit.BackendChain{stubBackend{}}
+	handler := AuthLiveKitTokenPreviewHandler(backends, "", nil, nil, nil)
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token/preview", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestAuthLiveKitTokenPreviewHandler_MFARequiredButMissing(t *testing.T) {
+	store := mfa.NewStore([]mfa.UserMFAConfig{{Identity: "alice", RequiredMethods: []string{"totp"}}})
+	gate := mfa.NewGate(store, map[string]mfa.Validator{"totp": mfa.NewTOTPValidator()})
+
+	sessionSecret := "test-session-secret"
+	handler := AuthLiveKitTokenPreviewHandler(nil, sessionSecret, nil, gate, nil)
+
+	token, err := livekit.NewSessionToken(sessionSecret, livekit.UserInfo{Identity: "alice"}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to mint session token: %v", err)
+	}
+
+	reqBody := AuthRequest{RoomName: "test-room"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token/preview", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: livekit.SessionCookieName, Value: token})
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAuthLiveKitTokenPreviewHandler_RequestedPermissionsNarrowButNeverBroaden(t *testing.T) {
+	engine := policy.NewEngine([]policy.Rule{
+		{RoomPattern: "lecture-*", Grant: policy.GrantSpec{CanPublish: boolPtr(true), RoomAdmin: boolPtr(false)}},
+	})
+
+	tests := []struct {
+		name           string
+		requested      *policy.GrantSpec
+		wantCanPublish bool
+		wantRoomAdmin  bool
+	}{
+		{
+			name:           "narrows CanPublish to false",
+			requested:      &policy.GrantSpec{CanPublish: boolPtr(false)},
+			wantCanPublish: false,
+			wantRoomAdmin:  false,
+		},
+		{
+			name:           "cannot broaden RoomAdmin beyond the matched rule",
+			requested:      &policy.GrantSpec{RoomAdmin: boolPtr(true)},
+			wantCanPublish: true,
+			wantRoomAdmin:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := AuthLiveKitTokenPreviewHandler(nil, "", nil, nil, engine)
+
+			reqBody := AuthRequest{RoomName: "lecture-hall", RequestedPermissions: tt.requested}
+			body, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest("POST", "/auth-livekit-token/preview", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp PreviewResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Permissions.GetCanPublish() != tt.wantCanPublish {
+				t.Errorf("expected CanPublish=%v, got %v", tt.wantCanPublish, resp.Permissions.GetCanPublish())
+			}
+			if resp.Permissions.RoomAdmin != tt.wantRoomAdmin {
+				t.Errorf("expected RoomAdmin=%v, got %v", tt.wantRoomAdmin, resp.Permissions.RoomAdmin)
+			}
+		})
+	}
+}
+
+func TestAuthLiveKitTokenPreviewHandler_PolicyEngineDeniesWhenNoRuleMatches(t *testing.T) {
+	engine := policy.NewEngine([]policy.Rule{
+		{RoomPattern: "lecture-*", Grant: policy.GrantSpec{CanPublish: boolPtr(true)}},
+	})
+	handler := AuthLiveKitTokenPreviewHandler(nil, "", nil, nil, engine)
+
+	reqBody := AuthRequest{RoomName: "standup"}
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/auth-livekit-token/preview", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
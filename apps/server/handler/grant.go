@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/livekit/protocol/auth"
+
+	"github.com/babelgopher/server/livekit"
+	"github.com/babelgopher/server/policy"
+)
+
+// computeGrant resolves the VideoGrant for user joining roomName. If
+// policyEngine is configured, it evaluates the declarative ruleset,
+// narrowing the result by requested if given; otherwise it falls back to
+// the simpler group-to-grant mapping.
+func computeGrant(roomName string, user livekit.UserInfo, grantMapping livekit.GroupGrantMapping, policyEngine *policy.Engine, requested *policy.GrantSpec) (*auth.VideoGrant, error) {
+	if policyEngine != nil {
+		builder, err := policyEngine.Evaluate(roomName, user, requested)
+		if err != nil {
+			return nil, err
+		}
+		return builder.Grant(), nil
+	}
+	return livekit.ComputeScopedGrant(roomName, user, grantMapping), nil
+}
+
+// mintToken signs a LiveKit JWT for identity carrying grant.
+func mintToken(apiKey, apiSecret, identity string, grant *auth.VideoGrant) (string, error) {
+	at := auth.NewAccessToken(apiKey, apiSecret)
+	at.AddGrant(grant).
+		SetIdentity(identity).
+		SetValidFor(24 * time.Hour)
+	return at.ToJWT()
+}
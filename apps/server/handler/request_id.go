@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// newRequestID generates a random hex identifier used to correlate a single
+// /auth-livekit-token call across its audit record and webhook deliveries.
+func newRequestID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("handler: generate request id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// clientIP extracts the caller's address from r.RemoteAddr, stripping the
+// port when present.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
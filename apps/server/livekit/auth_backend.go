@@ -0,0 +1,57 @@
+package livekit
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidCredentials is returned by an AuthBackend when the presented
+// credentials don't resolve to a user, so the caller can try the next
+// backend in the chain.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// UserInfo describes an authenticated caller, as resolved by an AuthBackend.
+type UserInfo struct {
+	Identity    string
+	DisplayName string
+	Groups      []string
+}
+
+// Credentials carries whatever the caller presented for authentication.
+// Each backend only looks at the fields relevant to it: htpasswd and static
+// backends use Username/Password, while the OAuth2 and bearer-JWT backends
+// use Token.
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// AuthBackend authenticates credentials and resolves the caller's identity.
+// Modeled on loginsrv's provider interface: implementations are stateless
+// and return ErrInvalidCredentials when the credentials don't apply to them,
+// so a BackendChain can fall through to the next backend.
+type AuthBackend interface {
+	Name() string
+	Authenticate(ctx context.Context, creds Credentials) (UserInfo, error)
+}
+
+// BackendChain tries a list of backends in order and returns the first
+// successful authentication.
+type BackendChain []AuthBackend
+
+// Authenticate runs creds through each backend in order. It returns
+// ErrInvalidCredentials only if every backend rejected the credentials;
+// any other error aborts the chain immediately.
+func (c BackendChain) Authenticate(ctx context.Context, creds Credentials) (UserInfo, error) {
+	for _, backend := range c {
+		info, err := backend.Authenticate(ctx, creds)
+		if err == nil {
+			return info, nil
+		}
+		if !errors.Is(err, ErrInvalidCredentials) {
+			return UserInfo{}, err
+		}
+	}
+	return UserInfo{}, ErrInvalidCredentials
+}
@@ -0,0 +1,55 @@
+package livekit
+
+import "testing"
+
+func TestGrantBuilder_DefaultsToJoinOnly(t *testing.T) {
+	grant := NewGrantBuilder("room-1").Grant()
+
+	if !grant.RoomJoin {
+		t.Error("expected RoomJoin to default to true")
+	}
+	if grant.Room != "room-1" {
+		t.Errorf("expected Room to be room-1, got %q", grant.Room)
+	}
+	if grant.RoomAdmin {
+		t.Error("expected RoomAdmin to default to false")
+	}
+}
+
+func TestGrantBuilder_SetsEveryField(t *testing.T) {
+	grant := NewGrantBuilder("room-1").
+		RoomAdmin(true).
+		RoomCreate(true).
+		RoomList(true).
+		RoomRecord(true).
+		CanPublish(false).
+		CanSubscribe(false).
+		CanPublishData(false).
+		CanPublishSources([]string{"camera"}).
+		Hidden(true).
+		Recorder(true).
+		Grant()
+
+	if !grant.RoomAdmin || !grant.RoomCreate || !grant.RoomList || !grant.RoomRecord {
+		t.Error("expected room-level booleans to be set")
+	}
+	if grant.GetCanPublish() || grant.GetCanSubscribe() || grant.GetCanPublishData() {
+		t.Error("expected publish/subscribe permissions to be false")
+	}
+	if len(grant.CanPublishSources) != 1 || grant.CanPublishSources[0] != "camera" {
+		t.Errorf("expected CanPublishSources to be [camera], got %v", grant.CanPublishSources)
+	}
+	if !grant.Hidden || !grant.Recorder {
+		t.Error("expected Hidden and Recorder to be true")
+	}
+}
+
+func TestGrantBuilder_Token(t *testing.T) {
+	token, err := NewGrantBuilder("room-1").CanPublish(false).Token("test-key", "test-secret", "alice")
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token == "" {
+		t.Error("expected non-empty token")
+	}
+}
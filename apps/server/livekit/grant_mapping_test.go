@@ -0,0 +1,53 @@
+package livekit
+
+import (
+	"testing"
+)
+
+func TestGenerateScopedToken_AppliesMatchingGroup(t *testing.T) {
+	canPublish := false
+	mapping := GroupGrantMapping{
+		"moderators": {RoomAdmin: true},
+		"listeners":  {CanPublish: &canPublish},
+	}
+	user := UserInfo{Identity: "alice", Groups: []string{"listeners"}}
+
+	token, grant, err := GenerateScopedToken("test-key", "test-secret", "lecture-hall", user, mapping)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken failed: %v", err)
+	}
+	if token == "" {
+		t.Error("expected non-empty token")
+	}
+	if grant.GetCanPublish() {
+		t.Error("expected the listeners group to deny publish")
+	}
+	if grant.RoomAdmin {
+		t.Error("expected RoomAdmin to stay false for a non-moderator")
+	}
+}
+
+func TestGenerateScopedToken_NoMatchingGroup(t *testing.T) {
+	mapping := GroupGrantMapping{"moderators": {RoomAdmin: true}}
+	user := UserInfo{Identity: "alice", Groups: []string{"guests"}}
+
+	token, _, err := GenerateScopedToken("test-key", "test-secret", "lecture-hall", user, mapping)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken failed: %v", err)
+	}
+	if token == "" {
+		t.Error("expected non-empty token")
+	}
+}
+
+func TestGenerateScopedToken_NilMapping(t *testing.T) {
+	user := UserInfo{Identity: "alice"}
+
+	token, _, err := GenerateScopedToken("test-key", "test-secret", "lecture-hall", user, nil)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken failed: %v", err)
+	}
+	if token == "" {
+		t.Error("expected non-empty token")
+	}
+}
@@ -0,0 +1,59 @@
+package livekit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HtpasswdBackend authenticates against an Apache-style htpasswd file.
+// Only bcrypt ($2a$/$2b$/$2y$) hashes are supported.
+type HtpasswdBackend struct {
+	path string
+}
+
+// NewHtpasswdBackend returns a backend that reads credentials from the
+// htpasswd file at path on every Authenticate call, so the file can be
+// edited without restarting the server.
+func NewHtpasswdBackend(path string) *HtpasswdBackend {
+	return &HtpasswdBackend{path: path}
+}
+
+func (b *HtpasswdBackend) Name() string { return "htpasswd" }
+
+func (b *HtpasswdBackend) Authenticate(ctx context.Context, creds Credentials) (UserInfo, error) {
+	if creds.Username == "" || creds.Password == "" {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	f, err := os.Open(b.path)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("htpasswd: open %s: %w", b.path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(line, ":")
+		if !ok || user != creds.Username {
+			continue
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(creds.Password)) != nil {
+			return UserInfo{}, ErrInvalidCredentials
+		}
+		return UserInfo{Identity: creds.Username}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return UserInfo{}, fmt.Errorf("htpasswd: read %s: %w", b.path, err)
+	}
+
+	return UserInfo{}, ErrInvalidCredentials
+}
@@ -0,0 +1,77 @@
+package livekit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeHtpasswdFile(t *testing.T, entries map[string]string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create htpasswd file: %v", err)
+	}
+	defer f.Close()
+
+	for user, password := range entries {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		if err != nil {
+			t.Fatalf("failed to hash password: %v", err)
+		}
+		if _, err := f.WriteString(user + ":" + string(hash) + "\n"); err != nil {
+			t.Fatalf("failed to write htpasswd entry: %v", err)
+		}
+	}
+
+	return path
+}
+
+func TestHtpasswdBackend_Authenticate(t *testing.T) {
+	path := writeHtpasswdFile(t, map[string]string{"alice": "correct-horse"})
+	backend := NewHtpasswdBackend(path)
+
+	user, err := backend.Authenticate(context.Background(), Credentials{Username: "alice", Password: "correct-horse"})
+	if err != nil {
+		t.Fatalf("expected successful authentication, got: %v", err)
+	}
+	if user.Identity != "alice" {
+		t.Errorf("expected identity %q, got %q", "alice", user.Identity)
+	}
+}
+
+func TestHtpasswdBackend_WrongPassword(t *testing.T) {
+	path := writeHtpasswdFile(t, map[string]string{"alice": "correct-horse"})
+	backend := NewHtpasswdBackend(path)
+
+	_, err := backend.Authenticate(context.Background(), Credentials{Username: "alice", Password: "wrong"})
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got: %v", err)
+	}
+}
+
+func TestHtpasswdBackend_UnknownUser(t *testing.T) {
+	path := writeHtpasswdFile(t, map[string]string{"alice": "correct-horse"})
+	backend := NewHtpasswdBackend(path)
+
+	_, err := backend.Authenticate(context.Background(), Credentials{Username: "bob", Password: "correct-horse"})
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got: %v", err)
+	}
+}
+
+func TestHtpasswdBackend_MissingCredentials(t *testing.T) {
+	path := writeHtpasswdFile(t, map[string]string{"alice": "correct-horse"})
+	backend := NewHtpasswdBackend(path)
+
+	_, err := backend.Authenticate(context.Background(), Credentials{})
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package livekit
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwk is a single RSA JSON Web Key as returned by a JWKS endpoint.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// BearerJWTBackend verifies a bearer token's signature against a remote JWKS
+// endpoint and trusts the token's subject claim as the caller's identity.
+type BearerJWTBackend struct {
+	jwksURL string
+	issuer  string
+	client  *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewBearerJWTBackend returns a backend that verifies RS256 tokens against
+// the JWKS published at jwksURL, requiring the given issuer.
+func NewBearerJWTBackend(jwksURL, issuer string) *BearerJWTBackend {
+	return &BearerJWTBackend{
+		jwksURL: jwksURL,
+		issuer:  issuer,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (b *BearerJWTBackend) Name() string { return "bearer-jwt" }
+
+func (b *BearerJWTBackend) Authenticate(ctx context.Context, creds Credentials) (UserInfo, error) {
+	if creds.Token == "" {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	keys, err := b.keySet(ctx)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("bearer-jwt: %w", err)
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(creds.Token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithIssuer(b.issuer))
+	if err != nil {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	return UserInfo{Identity: sub, Groups: stringSlice(claims["groups"])}, nil
+}
+
+// keySet returns the cached JWKS, refetching every 10 minutes.
+func (b *BearerJWTBackend) keySet(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.keys != nil && time.Since(b.fetchedAt) < 10*time.Minute {
+		return b.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned %d", resp.StatusCode)
+	}
+
+	var set jwks
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := rsaPublicKeyFromJWK(k)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	b.keys = keys
+	b.fetchedAt = time.Now()
+	return keys, nil
+}
+
+func rsaPublicKeyFromJWK(k jwk) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
+
+// stringSlice converts a JWT claim value of type []interface{} (as produced
+// by jwt.MapClaims) into a []string, ignoring non-string elements.
+func stringSlice(v interface{}) []string {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
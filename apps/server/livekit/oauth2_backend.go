@@ -0,0 +1,117 @@
+package livekit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OAuth2Config describes the endpoints and client credentials needed to
+// exchange an authorization code for an identity.
+type OAuth2Config struct {
+	TokenURL     string
+	UserInfoURL  string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// OAuth2Backend authenticates by exchanging an authorization code for an
+// access token and resolving the identity from the provider's userinfo
+// endpoint.
+type OAuth2Backend struct {
+	cfg    OAuth2Config
+	client *http.Client
+}
+
+// NewOAuth2Backend returns a backend configured for a single OAuth2 provider.
+func NewOAuth2Backend(cfg OAuth2Config) *OAuth2Backend {
+	return &OAuth2Backend{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (b *OAuth2Backend) Name() string { return "oauth2" }
+
+// Authenticate treats creds.Token as an authorization-code callback value.
+func (b *OAuth2Backend) Authenticate(ctx context.Context, creds Credentials) (UserInfo, error) {
+	if creds.Token == "" {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	accessToken, err := b.exchangeCode(ctx, creds.Token)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("oauth2: %w", err)
+	}
+
+	return b.fetchUserInfo(ctx, accessToken)
+}
+
+func (b *OAuth2Backend) exchangeCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {b.cfg.ClientID},
+		"client_secret": {b.cfg.ClientSecret},
+		"redirect_uri":  {b.cfg.RedirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("decode token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("token response missing access_token")
+	}
+
+	return tokenResp.AccessToken, nil
+}
+
+func (b *OAuth2Backend) fetchUserInfo(ctx context.Context, accessToken string) (UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.cfg.UserInfoURL, nil)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("oauth2: build userinfo request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("oauth2: fetch userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return UserInfo{}, fmt.Errorf("oauth2: userinfo endpoint returned %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Sub    string   `json:"sub"`
+		Name   string   `json:"name"`
+		Groups []string `json:"groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return UserInfo{}, fmt.Errorf("oauth2: decode userinfo: %w", err)
+	}
+	if info.Sub == "" {
+		return UserInfo{}, fmt.Errorf("oauth2: userinfo missing sub")
+	}
+
+	return UserInfo{Identity: info.Sub, DisplayName: info.Name, Groups: info.Groups}, nil
+}
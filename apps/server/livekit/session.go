@@ -0,0 +1,47 @@
+package livekit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SessionCookieName is the cookie AuthLiveKitTokenHandler looks for in lieu
+// of an Authorization header.
+const SessionCookieName = "babelgopher_session"
+
+// NewSessionToken mints a short-lived JWT that proves the caller already
+// passed the auth backend chain, mirroring loginsrv's own session cookie.
+func NewSessionToken(secret string, user UserInfo, ttl time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":    user.Identity,
+		"name":   user.DisplayName,
+		"groups": user.Groups,
+		"exp":    time.Now().Add(ttl).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseSessionToken verifies a session JWT minted by NewSessionToken.
+func ParseSessionToken(secret, tokenString string) (UserInfo, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return UserInfo{}, fmt.Errorf("session token missing sub claim")
+	}
+	name, _ := claims["name"].(string)
+
+	return UserInfo{Identity: sub, DisplayName: name, Groups: stringSlice(claims["groups"])}, nil
+}
@@ -0,0 +1,53 @@
+package livekit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionTokenRoundTrip(t *testing.T) {
+	secret := "test-secret"
+	user := UserInfo{Identity: "alice", DisplayName: "Alice", Groups: []string{"speakers"}}
+
+	token, err := NewSessionToken(secret, user, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSessionToken failed: %v", err)
+	}
+
+	got, err := ParseSessionToken(secret, token)
+	if err != nil {
+		t.Fatalf("ParseSessionToken failed: %v", err)
+	}
+
+	if got.Identity != user.Identity {
+		t.Errorf("expected identity %q, got %q", user.Identity, got.Identity)
+	}
+	if got.DisplayName != user.DisplayName {
+		t.Errorf("expected display name %q, got %q", user.DisplayName, got.DisplayName)
+	}
+	if len(got.Groups) != 1 || got.Groups[0] != "speakers" {
+		t.Errorf("expected groups [speakers], got %v", got.Groups)
+	}
+}
+
+func TestParseSessionToken_WrongSecret(t *testing.T) {
+	token, err := NewSessionToken("secret-a", UserInfo{Identity: "alice"}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSessionToken failed: %v", err)
+	}
+
+	if _, err := ParseSessionToken("secret-b", token); err == nil {
+		t.Error("expected error when verifying with the wrong secret")
+	}
+}
+
+func TestParseSessionToken_Expired(t *testing.T) {
+	token, err := NewSessionToken("test-secret", UserInfo{Identity: "alice"}, -time.Hour)
+	if err != nil {
+		t.Fatalf("NewSessionToken failed: %v", err)
+	}
+
+	if _, err := ParseSessionToken("test-secret", token); err == nil {
+		t.Error("expected error when verifying an expired token")
+	}
+}
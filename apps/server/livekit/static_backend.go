@@ -0,0 +1,62 @@
+package livekit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// staticUser is one entry in the JSON file loaded by StaticUserBackend.
+type staticUser struct {
+	Username     string   `json:"username"`
+	PasswordHash string   `json:"password_hash"` // bcrypt
+	DisplayName  string   `json:"display_name"`
+	Groups       []string `json:"groups"`
+}
+
+// StaticUserBackend authenticates against a fixed JSON list of users, useful
+// for small deployments that don't warrant a full identity provider.
+type StaticUserBackend struct {
+	users map[string]staticUser
+}
+
+// NewStaticUserBackend loads the JSON user list at path once at startup.
+func NewStaticUserBackend(path string) (*StaticUserBackend, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("static backend: read %s: %w", path, err)
+	}
+
+	var list []staticUser
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("static backend: parse %s: %w", path, err)
+	}
+
+	users := make(map[string]staticUser, len(list))
+	for _, u := range list {
+		users[u.Username] = u
+	}
+
+	return &StaticUserBackend{users: users}, nil
+}
+
+func (b *StaticUserBackend) Name() string { return "static" }
+
+func (b *StaticUserBackend) Authenticate(ctx context.Context, creds Credentials) (UserInfo, error) {
+	if creds.Username == "" || creds.Password == "" {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	u, ok := b.users[creds.Username]
+	if !ok {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(creds.Password)) != nil {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+
+	return UserInfo{Identity: u.Username, DisplayName: u.DisplayName, Groups: u.Groups}, nil
+}
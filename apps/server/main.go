@@ -4,8 +4,12 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
+	"github.com/babelgopher/server/config"
 	"github.com/babelgopher/server/handler"
+	"github.com/babelgopher/server/middleware"
+	"github.com/babelgopher/server/oidc"
 )
 
 func main() {
@@ -20,9 +24,6 @@ func main() {
 	livekitAPISecret := os.Getenv("LIVEKIT_API_SECRET")
 	livekitURL := os.Getenv("LIVEKIT_URL")
 
-	// Load CORS configuration
-	frontendURL := os.Getenv("FRONTEND_URL")
-
 	// Validate required LiveKit environment variables
 	if livekitAPIKey == "" || livekitAPISecret == "" {
 		log.Println("WARNING: LIVEKIT_API_KEY or LIVEKIT_API_SECRET not set")
@@ -33,16 +34,88 @@ func main() {
 		log.Println("INFO: LIVEKIT_URL not set (optional, used by clients)")
 	}
 
-	if frontendURL == "" {
-		log.Println("WARNING: FRONTEND_URL not set - CORS will allow all origins (*)")
-		log.Println("For production, set FRONTEND_URL to your frontend domain (e.g., https://yourdomain.vercel.app)")
+	// Load CORS configuration (ALLOWED_ORIGINS=https://app.example.com,https://*.example.com)
+	corsOpts := config.LoadCORSOptions()
+	if os.Getenv("ALLOWED_ORIGINS") == "" {
+		log.Println("WARNING: ALLOWED_ORIGINS not set - CORS will allow all origins (*)")
+		log.Println("For production, set ALLOWED_ORIGINS to your frontend domain(s) (e.g., https://yourdomain.vercel.app)")
+	} else {
+		log.Printf("INFO: CORS configured for origins: %s", strings.Join(corsOpts.AllowedOrigins, ", "))
+	}
+
+	// Load auth backend chain (AUTH_BACKENDS=htpasswd,oauth2,...)
+	authConfig, err := config.LoadAuthConfig()
+	if err != nil {
+		log.Fatalf("failed to load auth config: %v", err)
+	}
+	if len(authConfig.Backends) == 0 {
+		log.Println("WARNING: AUTH_BACKENDS not set - /auth-livekit-token will accept any caller as \"anonymous\"")
 	} else {
-		log.Printf("INFO: CORS configured for frontend origin: %s", frontendURL)
+		log.Printf("INFO: auth backends enabled: %s", os.Getenv("AUTH_BACKENDS"))
+	}
+
+	// Load group-to-grant mapping (GROUP_GRANT_MAPPING_FILE=mapping.yaml)
+	grantMapping, err := config.LoadGroupGrantMapping(os.Getenv("GROUP_GRANT_MAPPING_FILE"))
+	if err != nil {
+		log.Fatalf("failed to load group grant mapping: %v", err)
+	}
+
+	// Load MFA enforcement (MFA_CONFIG_FILE or MFA_CONFIG_JSON)
+	mfaGate, mfaEnabled, err := config.LoadMFAGate()
+	if err != nil {
+		log.Fatalf("failed to load MFA config: %v", err)
+	}
+	if mfaEnabled {
+		log.Println("INFO: MFA enforcement enabled for enrolled identities")
+	}
+
+	// Load the audit sink (AUDIT_SINK=stdout|file)
+	auditSink, err := config.LoadAuditSink()
+	if err != nil {
+		log.Fatalf("failed to load audit sink: %v", err)
 	}
 
-	// Register routes
-	http.HandleFunc("/health", handler.HealthHandler)
-	http.HandleFunc("/auth-livekit-token", handler.AuthLiveKitTokenHandler(livekitAPIKey, livekitAPISecret, frontendURL))
+	// Load webhook delivery (WEBHOOK_CONFIG_FILE=webhooks.json)
+	webhookDispatcher, err := config.LoadWebhookDispatcher()
+	if err != nil {
+		log.Fatalf("failed to load webhook config: %v", err)
+	}
+	if webhookDispatcher != nil {
+		log.Println("INFO: webhook notifications enabled")
+		http.Handle("/metrics", webhookDispatcher.MetricsHandler())
+	}
+
+	// Load the room policy engine (POLICY_RULESET_FILE=policy.yaml), which
+	// takes over grant computation from grantMapping when configured
+	policyEngine, policyEnabled, err := config.LoadPolicyEngine()
+	if err != nil {
+		log.Fatalf("failed to load policy engine: %v", err)
+	}
+	if policyEnabled {
+		log.Println("INFO: room policy engine enabled, overriding group-to-grant mapping")
+	}
+
+	// Register routes, applying CORS uniformly via a shared registry so
+	// preflight responses reflect each path's actual allowed methods
+	registry := middleware.NewRegistry()
+	cors := middleware.CORS(corsOpts, registry)
+
+	registry.Register("/health", http.MethodGet)
+	http.Handle("/health", cors(http.HandlerFunc(handler.HealthHandler)))
+
+	registry.Register("/auth-livekit-token", http.MethodPost)
+	http.Handle("/auth-livekit-token", cors(handler.AuthLiveKitTokenHandler(livekitAPIKey, livekitAPISecret, authConfig.Backends, authConfig.SessionSecret, grantMapping, mfaGate, auditSink, webhookDispatcher, policyEngine)))
+
+	registry.Register("/auth-livekit-token/preview", http.MethodPost)
+	http.Handle("/auth-livekit-token/preview", cors(handler.AuthLiveKitTokenPreviewHandler(authConfig.Backends, authConfig.SessionSecret, grantMapping, mfaGate, policyEngine)))
+
+	// Register OIDC single sign-on routes, if configured
+	if oidcConfig, ok := config.LoadOIDCConfig(authConfig.SessionSecret); ok {
+		oidcHandler := oidc.NewHandler(oidcConfig)
+		http.HandleFunc("/auth/oidc/login", oidcHandler.LoginHandler)
+		http.HandleFunc("/auth/oidc/callback", oidcHandler.CallbackHandler)
+		log.Printf("INFO: OIDC single sign-on enabled via issuer %s", oidcConfig.IssuerURL)
+	}
 
 	// Start server
 	addr := "0.0.0.0:" + port
@@ -50,6 +123,10 @@ func main() {
 	log.Printf("Endpoints available:")
 	log.Printf("  GET  /health")
 	log.Printf("  POST /auth-livekit-token")
+	log.Printf("  POST /auth-livekit-token/preview")
+	if webhookDispatcher != nil {
+		log.Printf("  GET  /metrics")
+	}
 
 	if err := http.ListenAndServe(addr, nil); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,26 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+)
+
+// BackupCodeValidator checks a passcode against a user's single-use backup
+// codes, consuming the code from the store on success so it can't be replayed.
+type BackupCodeValidator struct {
+	store *Store
+}
+
+// NewBackupCodeValidator returns a validator backed by store.
+func NewBackupCodeValidator(store *Store) *BackupCodeValidator {
+	return &BackupCodeValidator{store: store}
+}
+
+func (v *BackupCodeValidator) Validate(ctx context.Context, user UserMFAConfig, passcodes []string) error {
+	for _, code := range passcodes {
+		if v.store.ConsumeBackupCode(user.Identity, code) {
+			return nil
+		}
+	}
+	return errors.New("backup code: no matching unused code")
+}
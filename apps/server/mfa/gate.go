@@ -0,0 +1,50 @@
+package mfa
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Gate enforces per-user MFA requirements before token issuance.
+type Gate struct {
+	store      *Store
+	validators map[string]Validator
+}
+
+// NewGate returns a Gate that resolves MFA requirements from store and
+// checks each required method against the validators keyed by method name.
+func NewGate(store *Store, validators map[string]Validator) *Gate {
+	return &Gate{store: store, validators: validators}
+}
+
+// Require checks the MFA credentials on r against every method required for
+// identity. It returns nil if identity has no MFA requirements configured.
+func (g *Gate) Require(r *http.Request, identity string) error {
+	user, ok := g.store.Lookup(identity)
+	if !ok || len(user.RequiredMethods) == 0 {
+		return nil
+	}
+
+	creds, err := parseMFAHeader(r)
+	if err != nil {
+		return err
+	}
+
+	for _, method := range user.RequiredMethods {
+		validator, ok := g.validators[method]
+		if !ok {
+			return fmt.Errorf("mfa: no validator configured for method %q", method)
+		}
+
+		passcodes, ok := creds[method]
+		if !ok || len(passcodes) == 0 {
+			return fmt.Errorf("mfa: missing credentials for required method %q", method)
+		}
+
+		if err := validator.Validate(r.Context(), user, passcodes); err != nil {
+			return fmt.Errorf("mfa: method %q failed: %w", method, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+// alwaysValidator either always accepts or always rejects, for exercising Gate.
+type alwaysValidator struct{ ok bool }
+
+func (v alwaysValidator) Validate(ctx context.Context, user UserMFAConfig, passcodes []string) error {
+	if v.ok {
+		return nil
+	}
+	return errors.New("rejected")
+}
+
+func TestGate_Require_NotEnrolled(t *testing.T) {
+	store := NewStore(nil)
+	gate := NewGate(store, nil)
+
+	r := httptest.NewRequest("POST", "/", nil)
+	if err := gate.Require(r, "alice"); err != nil {
+		t.Errorf("expected no MFA requirement for an unenrolled identity, got: %v", err)
+	}
+}
+
+func TestGate_Require_MissingCredentials(t *testing.T) {
+	store := NewStore([]UserMFAConfig{{Identity: "alice", RequiredMethods: []string{"totp"}}})
+	gate := NewGate(store, map[string]Validator{"totp": alwaysValidator{ok: true}})
+
+	r := httptest.NewRequest("POST", "/", nil)
+	if err := gate.Require(r, "alice"); err == nil {
+		t.Error("expected an error when required MFA credentials are missing")
+	}
+}
+
+func TestGate_Require_Success(t *testing.T) {
+	store := NewStore([]UserMFAConfig{{Identity: "alice", RequiredMethods: []string{"totp"}}})
+	gate := NewGate(store, map[string]Validator{"totp": alwaysValidator{ok: true}})
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Add(HeaderName, "totp:123456")
+	if err := gate.Require(r, "alice"); err != nil {
+		t.Errorf("expected success, got: %v", err)
+	}
+}
+
+func TestGate_Require_ValidatorFailure(t *testing.T) {
+	store := NewStore([]UserMFAConfig{{Identity: "alice", RequiredMethods: []string{"totp"}}})
+	gate := NewGate(store, map[string]Validator{"totp": alwaysValidator{ok: false}})
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Add(HeaderName, "totp:123456")
+	if err := gate.Require(r, "alice"); err == nil {
+		t.Error("expected an error when the validator rejects the passcode")
+	}
+}
@@ -0,0 +1,49 @@
+package mfa
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderName is the request header carrying MFA credentials, mirroring
+// Vault's X-Vault-MFA convention.
+const HeaderName = "X-BabelGopher-MFA"
+
+// parseMFAHeader reads every X-BabelGopher-MFA header on r into a map of
+// method name to the passcodes supplied for it. Each header value is a
+// comma-separated list of "method_name:passcode" entries; repeated headers
+// and multiple comma-separated values all merge into the same map. A method
+// named with no colon (no passcode) still gets an entry, with an empty
+// slice, so callers can tell "method required but no passcode given" apart
+// from "method not mentioned at all".
+func parseMFAHeader(r *http.Request) (map[string][]string, error) {
+	result := make(map[string][]string)
+
+	for _, header := range r.Header.Values(HeaderName) {
+		for _, entry := range strings.Split(header, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			method, passcode, hasColon := strings.Cut(entry, ":")
+			method = strings.TrimSpace(method)
+			if method == "" {
+				return nil, fmt.Errorf("mfa: header entry %q is missing a method name", entry)
+			}
+
+			if !hasColon {
+				if _, ok := result[method]; !ok {
+					result[method] = []string{}
+				}
+				continue
+			}
+
+			passcode = strings.TrimSpace(passcode)
+			result[method] = append(result[method], passcode)
+		}
+	}
+
+	return result, nil
+}
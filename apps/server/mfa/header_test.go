@@ -0,0 +1,62 @@
+package mfa
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseMFAHeader_MultipleValuesAndHeaders(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Add(HeaderName, "totp:123456,backup-code:abcd1234")
+	r.Header.Add(HeaderName, "totp:654321")
+
+	got, err := parseMFAHeader(r)
+	if err != nil {
+		t.Fatalf("parseMFAHeader failed: %v", err)
+	}
+
+	want := map[string][]string{
+		"totp":        {"123456", "654321"},
+		"backup-code": {"abcd1234"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseMFAHeader_NoColonProducesEmptySlice(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Add(HeaderName, "totp")
+
+	got, err := parseMFAHeader(r)
+	if err != nil {
+		t.Fatalf("parseMFAHeader failed: %v", err)
+	}
+
+	want := map[string][]string{"totp": {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseMFAHeader_NoHeader(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+
+	got, err := parseMFAHeader(r)
+	if err != nil {
+		t.Fatalf("parseMFAHeader failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected empty map, got %v", got)
+	}
+}
+
+func TestParseMFAHeader_MissingMethodName(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Add(HeaderName, ":123456")
+
+	if _, err := parseMFAHeader(r); err == nil {
+		t.Error("expected an error for a header entry with no method name")
+	}
+}
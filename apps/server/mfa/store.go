@@ -0,0 +1,88 @@
+package mfa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// UserMFAConfig is one user's MFA enrollment: which methods are required,
+// and the per-method secrets needed to validate them.
+type UserMFAConfig struct {
+	Identity        string   `json:"identity"`
+	RequiredMethods []string `json:"required_methods"`
+	TOTPSecret      string   `json:"totp_secret,omitempty"`  // base32, RFC 6238
+	BackupCodes     []string `json:"backup_codes,omitempty"` // single-use
+}
+
+// Store holds each user's MFA enrollment in memory. Backup codes are
+// consumed on use, so Store is mutable and safe for concurrent use.
+type Store struct {
+	mu    sync.Mutex
+	users map[string]*UserMFAConfig
+}
+
+// NewStore builds a Store from a fixed list of user configs.
+func NewStore(users []UserMFAConfig) *Store {
+	m := make(map[string]*UserMFAConfig, len(users))
+	for i := range users {
+		u := users[i]
+		m[u.Identity] = &u
+	}
+	return &Store{users: m}
+}
+
+// LoadStoreFromFile reads a JSON array of UserMFAConfig from path.
+func LoadStoreFromFile(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("mfa: read %s: %w", path, err)
+	}
+	return loadStoreFromJSON(data)
+}
+
+// LoadStoreFromEnv parses a JSON array of UserMFAConfig directly from raw,
+// for small deployments that would rather not manage a separate file.
+func LoadStoreFromEnv(raw string) (*Store, error) {
+	return loadStoreFromJSON([]byte(raw))
+}
+
+func loadStoreFromJSON(data []byte) (*Store, error) {
+	var users []UserMFAConfig
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("mfa: parse user config: %w", err)
+	}
+	return NewStore(users), nil
+}
+
+// Lookup returns the MFA config for identity, if any.
+func (s *Store) Lookup(identity string) (UserMFAConfig, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[identity]
+	if !ok {
+		return UserMFAConfig{}, false
+	}
+	return *u, true
+}
+
+// ConsumeBackupCode checks whether code is an unused backup code for
+// identity and, if so, removes it so it cannot be replayed.
+func (s *Store) ConsumeBackupCode(identity, code string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[identity]
+	if !ok {
+		return false
+	}
+	for i, c := range u.BackupCodes {
+		if c == code {
+			u.BackupCodes = append(u.BackupCodes[:i], u.BackupCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
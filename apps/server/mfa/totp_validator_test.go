@@ -0,0 +1,40 @@
+package mfa
+
+import (
+	"context"
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+func TestTOTPValidator_Validate(t *testing.T) {
+	secretBytes := []byte("12345678901234567890")
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secretBytes)
+	user := UserMFAConfig{Identity: "alice", TOTPSecret: secret}
+
+	fixedNow := time.Unix(59, 0)
+	v := &TOTPValidator{skew: 1, now: func() time.Time { return fixedNow }}
+	code := generateTOTP(secretBytes, uint64(fixedNow.Unix())/30)
+
+	if err := v.Validate(context.Background(), user, []string{code}); err != nil {
+		t.Errorf("expected valid code to pass, got: %v", err)
+	}
+}
+
+func TestTOTPValidator_WrongCode(t *testing.T) {
+	secretBytes := []byte("12345678901234567890")
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secretBytes)
+	user := UserMFAConfig{Identity: "alice", TOTPSecret: secret}
+
+	v := NewTOTPValidator()
+	if err := v.Validate(context.Background(), user, []string{"000000"}); err == nil {
+		t.Error("expected an invalid code to fail")
+	}
+}
+
+func TestTOTPValidator_NoSecretConfigured(t *testing.T) {
+	v := NewTOTPValidator()
+	if err := v.Validate(context.Background(), UserMFAConfig{Identity: "alice"}, []string{"123456"}); err == nil {
+		t.Error("expected an error when no TOTP secret is configured")
+	}
+}
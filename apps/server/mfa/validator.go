@@ -0,0 +1,9 @@
+package mfa
+
+import "context"
+
+// Validator checks the passcodes presented for a single MFA method against
+// a user's enrollment.
+type Validator interface {
+	Validate(ctx context.Context, user UserMFAConfig, passcodes []string) error
+}
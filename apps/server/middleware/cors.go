@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options configures the CORS middleware returned by CORS.
+type Options struct {
+	// AllowedOrigins lists the origins eligible for cross-origin access.
+	// Entries may be an exact origin (e.g. "https://app.example.com"), a
+	// wildcard-subdomain pattern (e.g. "https://*.example.com"), or "*" to
+	// allow any origin.
+	AllowedOrigins []string
+	// AllowedHeaders lists the request headers a preflight may ask for.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers a browser script may read.
+	ExposedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials on allowed
+	// requests, permitting cookies/Authorization headers to be sent.
+	AllowCredentials bool
+	// MaxAge controls how long a browser may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// CORS returns middleware enforcing opts against routes tracked in
+// registry. On an OPTIONS preflight it echoes the request's Origin only if
+// allowed, answers Access-Control-Allow-Methods with the methods registry
+// actually has registered for the request path, and always sets Vary so
+// caches don't serve one origin's preflight response to another.
+func CORS(opts Options, registry *Registry) func(http.Handler) http.Handler {
+	allowedHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Add("Vary", "Origin")
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+
+			origin := r.Header.Get("Origin")
+			allowed := origin != "" && originAllowed(origin, opts.AllowedOrigins)
+			if allowed {
+				header.Set("Access-Control-Allow-Origin", origin)
+				if opts.AllowCredentials {
+					header.Set("Access-Control-Allow-Credentials", "true")
+				}
+				if exposedHeaders != "" {
+					header.Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+			}
+
+			if r.Method == http.MethodOptions {
+				if allowed {
+					header.Set("Access-Control-Allow-Methods", strings.Join(registry.MethodsFor(r.URL.Path), ", "))
+					if allowedHeaders != "" {
+						header.Set("Access-Control-Allow-Headers", allowedHeaders)
+					}
+					if opts.MaxAge > 0 {
+						header.Set("Access-Control-Max-Age", maxAge)
+					}
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originAllowed reports whether origin matches any pattern in allowed.
+func originAllowed(origin string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if matchOrigin(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrigin reports whether origin satisfies pattern, which is either an
+// exact origin, "*", or a wildcard-subdomain pattern such as
+// "https://*.example.com" (matching "https://foo.example.com" but not
+// "https://example.com" itself).
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+
+	star := strings.Index(pattern, "*.")
+	if star == -1 {
+		return false
+	}
+
+	prefix, suffix := pattern[:star], pattern[star+1:] // suffix keeps the leading "."
+	if !strings.HasPrefix(origin, prefix) {
+		return false
+	}
+	subdomain := strings.TrimSuffix(origin[len(prefix):], suffix)
+	return subdomain != "" && strings.HasSuffix(origin, suffix)
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCORS(t *testing.T, credentials bool) (http.Handler, *Registry) {
+	t.Helper()
+	registry := NewRegistry()
+	registry.Register("/widgets", http.MethodGet)
+	registry.Register("/widgets", http.MethodPost)
+
+	opts := Options{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.staging.example.com"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-Id"},
+		AllowCredentials: credentials,
+		MaxAge:           10 * time.Minute,
+	}
+
+	handler := CORS(opts, registry)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return handler, registry
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Origin for disallowed origin, got %q", got)
+	}
+}
+
+func TestCORS_ExactOriginAllowed(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("expected origin to be echoed back, got %q", got)
+	}
+}
+
+func TestCORS_SubdomainWildcardMatch(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Origin", "https://pr-42.staging.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://pr-42.staging.example.com" {
+		t.Errorf("expected subdomain origin to be echoed back, got %q", got)
+	}
+}
+
+func TestCORS_SubdomainWildcardRejectsBareDomain(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Origin", "https://staging.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected bare domain not to match the subdomain wildcard, got %q", got)
+	}
+}
+
+func TestCORS_PreflightAllowMethodsFromRegistry(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodOptions, "/widgets", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204 for preflight, got %d", rec.Code)
+	}
+	got := rec.Header().Get("Access-Control-Allow-Methods")
+	for _, method := range []string{"GET", "POST", "OPTIONS"} {
+		if !containsToken(got, method) {
+			t.Errorf("expected Access-Control-Allow-Methods %q to contain %s", got, method)
+		}
+	}
+}
+
+func TestCORS_CredentialedRequestSetsAllowCredentials(t *testing.T) {
+	handler, _ := newTestCORS(t, true)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected Access-Control-Allow-Credentials to be true, got %q", got)
+	}
+}
+
+func TestCORS_VaryHeadersAlwaysSet(t *testing.T) {
+	handler, _ := newTestCORS(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	vary := rec.Header().Values("Vary")
+	for _, want := range []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"} {
+		found := false
+		for _, v := range vary {
+			if v == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected Vary to include %q, got %v", want, vary)
+		}
+	}
+}
+
+func containsToken(list, token string) bool {
+	for _, part := range strings.Split(list, ", ") {
+		if part == token {
+			return true
+		}
+	}
+	return false
+}
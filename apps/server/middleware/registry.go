@@ -0,0 +1,38 @@
+package middleware
+
+import "sort"
+
+// Registry tracks which HTTP methods are registered for each path, so CORS
+// can answer preflight requests with the Access-Control-Allow-Methods that
+// the path actually supports, instead of each call site repeating its own
+// list.
+type Registry struct {
+	methods map[string]map[string]bool
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{methods: map[string]map[string]bool{}}
+}
+
+// Register records that method is handled for path.
+func (reg *Registry) Register(path, method string) {
+	if reg.methods[path] == nil {
+		reg.methods[path] = map[string]bool{}
+	}
+	reg.methods[path][method] = true
+}
+
+// MethodsFor returns the sorted, deduplicated set of methods registered for
+// path, always including OPTIONS since every registered route accepts a
+// CORS preflight.
+func (reg *Registry) MethodsFor(path string) []string {
+	set := reg.methods[path]
+	methods := make([]string, 0, len(set)+1)
+	methods = append(methods, "OPTIONS")
+	for method := range set {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,22 @@
+package oidc
+
+import "time"
+
+// Config holds the provider and session settings for the OIDC
+// authorization-code flow served at /auth/oidc/login and /auth/oidc/callback.
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// GroupsClaim is the ID token claim read as the caller's groups/roles.
+	// Defaults to "groups".
+	GroupsClaim string
+
+	// SessionSecret signs the session cookie minted after a successful callback.
+	SessionSecret string
+	// SessionTTL is how long that cookie stays valid. Defaults to 1 hour.
+	SessionTTL time.Duration
+}
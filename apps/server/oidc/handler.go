@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+// Handler implements the OIDC authorization-code flow: LoginHandler
+// redirects the caller to the provider, and CallbackHandler completes the
+// exchange and mints a BabelGopher session cookie.
+type Handler struct {
+	cfg Config
+
+	states *stateStore
+
+	mu        sync.Mutex
+	provider  *oidc.Provider
+	oauth2Cfg oauth2.Config
+	verifier  *oidc.IDTokenVerifier
+}
+
+// NewHandler returns an OIDC handler for cfg. Provider discovery happens
+// lazily on the first request so a misconfigured or unreachable issuer
+// doesn't prevent the server from starting.
+func NewHandler(cfg Config) *Handler {
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if cfg.SessionTTL == 0 {
+		cfg.SessionTTL = time.Hour
+	}
+	return &Handler{cfg: cfg, states: newStateStore(5 * time.Minute)}
+}
+
+func (h *Handler) ensureProvider(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.provider != nil {
+		return nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, h.cfg.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("oidc: discover issuer %s: %w", h.cfg.IssuerURL, err)
+	}
+
+	scopes := h.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+
+	h.provider = provider
+	h.oauth2Cfg = oauth2.Config{
+		ClientID:     h.cfg.ClientID,
+		ClientSecret: h.cfg.ClientSecret,
+		RedirectURL:  h.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	h.verifier = provider.Verifier(&oidc.Config{ClientID: h.cfg.ClientID})
+	return nil
+}
+
+// LoginHandler handles GET /auth/oidc/login by redirecting to the
+// provider's authorization endpoint with a fresh state, nonce, and PKCE
+// challenge.
+func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.ensureProvider(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	state, err := randomString(32)
+	if err != nil {
+		http.Error(w, "failed to generate state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		http.Error(w, "failed to generate nonce: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	verifier, err := randomString(64)
+	if err != nil {
+		http.Error(w, "failed to generate PKCE verifier: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.states.put(state, pendingAuth{nonce: nonce, verifier: verifier})
+
+	authURL := h.oauth2Cfg.AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", pkceChallenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// CallbackHandler handles GET /auth/oidc/callback: it completes the
+// authorization-code exchange, verifies the ID token, and mints a session
+// cookie carrying the caller's identity and groups/roles claim. Once this
+// succeeds, AuthLiveKitTokenHandler takes the caller's identity from that
+// cookie.
+func (h *Handler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.ensureProvider(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pending, ok := h.states.take(r.URL.Query().Get("state"))
+	if !ok {
+		http.Error(w, "invalid or expired state", http.StatusBadRequest)
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "missing code", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.oauth2Cfg.Exchange(r.Context(), code,
+		oauth2.SetAuthURLParam("code_verifier", pending.verifier),
+	)
+	if err != nil {
+		http.Error(w, "failed to exchange code: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		http.Error(w, "token response missing id_token", http.StatusBadGateway)
+		return
+	}
+
+	idToken, err := h.verifier.Verify(r.Context(), rawIDToken)
+	if err != nil {
+		http.Error(w, "id token verification failed: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if idToken.Nonce != pending.nonce {
+		http.Error(w, "nonce mismatch", http.StatusUnauthorized)
+		return
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+		Name    string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		http.Error(w, "failed to parse claims: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := readGroupsClaim(idToken, h.cfg.GroupsClaim)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user := livekit.UserInfo{Identity: claims.Subject, DisplayName: claims.Name, Groups: groups}
+	session, err := livekit.NewSessionToken(h.cfg.SessionSecret, user, h.cfg.SessionTTL)
+	if err != nil {
+		http.Error(w, "failed to mint session: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     livekit.SessionCookieName,
+		Value:    session,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(h.cfg.SessionTTL.Seconds()),
+	})
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "signed in")
+}
+
+// readGroupsClaim extracts a string-array claim named claimName from the ID
+// token, returning nil if the claim is absent.
+func readGroupsClaim(idToken *oidc.IDToken, claimName string) ([]string, error) {
+	var raw map[string]interface{}
+	if err := idToken.Claims(&raw); err != nil {
+		return nil, fmt.Errorf("oidc: parse claims: %w", err)
+	}
+
+	values, ok := raw[claimName].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	groups := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			groups = append(groups, s)
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRandomString_Unique(t *testing.T) {
+	a, err := randomString(32)
+	if err != nil {
+		t.Fatalf("randomString failed: %v", err)
+	}
+	b, err := randomString(32)
+	if err != nil {
+		t.Fatalf("randomString failed: %v", err)
+	}
+	if a == b {
+		t.Error("expected two random strings to differ")
+	}
+}
+
+func TestPKCEChallenge_Deterministic(t *testing.T) {
+	verifier := "a-fixed-test-verifier"
+	if pkceChallenge(verifier) != pkceChallenge(verifier) {
+		t.Error("expected pkceChallenge to be deterministic for the same verifier")
+	}
+}
+
+func TestStateStore_TakeConsumesEntry(t *testing.T) {
+	store := newStateStore(time.Minute)
+	store.put("state-1", pendingAuth{nonce: "n", verifier: "v"})
+
+	p, ok := store.take("state-1")
+	if !ok {
+		t.Fatal("expected state-1 to be present")
+	}
+	if p.nonce != "n" || p.verifier != "v" {
+		t.Errorf("unexpected pendingAuth: %+v", p)
+	}
+
+	if _, ok := store.take("state-1"); ok {
+		t.Error("expected state-1 to be consumed after the first take")
+	}
+}
+
+func TestStateStore_ExpiredEntryRejected(t *testing.T) {
+	store := newStateStore(-time.Minute)
+	store.put("state-1", pendingAuth{nonce: "n", verifier: "v"})
+
+	if _, ok := store.take("state-1"); ok {
+		t.Error("expected an already-expired entry to be rejected")
+	}
+}
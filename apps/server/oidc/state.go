@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"sync"
+	"time"
+)
+
+// pendingAuth is what /auth/oidc/login stashes for an in-flight login so the
+// callback can recover it once the provider redirects back.
+type pendingAuth struct {
+	nonce     string
+	verifier  string
+	expiresAt time.Time
+}
+
+// stateStore holds pendingAuth values keyed by the OAuth2 state parameter.
+// Entries expire after ttl to bound memory if a login is abandoned.
+type stateStore struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	pending map[string]pendingAuth
+}
+
+func newStateStore(ttl time.Duration) *stateStore {
+	return &stateStore{ttl: ttl, pending: make(map[string]pendingAuth)}
+}
+
+func (s *stateStore) put(state string, p pendingAuth) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.expiresAt = time.Now().Add(s.ttl)
+	s.pending[state] = p
+	s.gc()
+}
+
+// take removes and returns the pendingAuth for state, if present and unexpired.
+func (s *stateStore) take(state string) (pendingAuth, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.pending[state]
+	delete(s.pending, state)
+	if !ok || time.Now().After(p.expiresAt) {
+		return pendingAuth{}, false
+	}
+	return p, true
+}
+
+// gc drops expired entries. Callers must hold s.mu.
+func (s *stateStore) gc() {
+	now := time.Now()
+	for state, p := range s.pending {
+		if now.After(p.expiresAt) {
+			delete(s.pending, state)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"errors"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+// ErrNoMatchingRule is returned when no rule in the engine matches the
+// given room and identity.
+var ErrNoMatchingRule = errors.New("policy: no rule matches this room and identity")
+
+// Engine evaluates a declarative ruleset to produce the VideoGrant for an
+// identity joining a room.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine builds an Engine from rules.
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Evaluate returns the GrantBuilder for identity joining roomName, having
+// applied the most specific matching rule (see Rule.specificity). requested,
+// if non-nil, narrows the result further but can never widen it beyond
+// what the matched rule allows.
+func (e *Engine) Evaluate(roomName string, user livekit.UserInfo, requested *GrantSpec) (*livekit.GrantBuilder, error) {
+	rule, ok := e.bestMatch(roomName, user)
+	if !ok {
+		return nil, ErrNoMatchingRule
+	}
+
+	builder := livekit.NewGrantBuilder(roomName)
+	rule.Grant.applyTo(builder)
+
+	if requested != nil {
+		narrow(builder, *requested)
+	}
+
+	return builder, nil
+}
+
+func (e *Engine) bestMatch(roomName string, user livekit.UserInfo) (Rule, bool) {
+	var (
+		best      Rule
+		bestFound bool
+		bestPat   int
+		bestSel   int
+	)
+
+	for _, rule := range e.rules {
+		if !rule.matches(roomName, user) {
+			continue
+		}
+		pat, sel := rule.specificity()
+		if !bestFound || pat > bestPat || (pat == bestPat && sel > bestSel) {
+			best, bestFound, bestPat, bestSel = rule, true, pat, sel
+		}
+	}
+
+	return best, bestFound
+}
+
+// narrow tightens every field requested explicitly sets, never loosening
+// beyond what the matched rule already granted.
+func narrow(b *livekit.GrantBuilder, requested GrantSpec) {
+	grant := b.Grant()
+
+	if requested.RoomAdmin != nil {
+		b.RoomAdmin(grant.RoomAdmin && *requested.RoomAdmin)
+	}
+	if requested.RoomCreate != nil {
+		b.RoomCreate(grant.RoomCreate && *requested.RoomCreate)
+	}
+	if requested.RoomList != nil {
+		b.RoomList(grant.RoomList && *requested.RoomList)
+	}
+	if requested.RoomRecord != nil {
+		b.RoomRecord(grant.RoomRecord && *requested.RoomRecord)
+	}
+	if requested.CanPublish != nil {
+		b.CanPublish(grant.GetCanPublish() && *requested.CanPublish)
+	}
+	if requested.CanSubscribe != nil {
+		b.CanSubscribe(grant.GetCanSubscribe() && *requested.CanSubscribe)
+	}
+	if requested.CanPublishData != nil {
+		b.CanPublishData(grant.GetCanPublishData() && *requested.CanPublishData)
+	}
+	if requested.Recorder != nil {
+		b.Recorder(grant.Recorder && *requested.Recorder)
+	}
+	if requested.Hidden != nil {
+		// A client asking to be hidden should never be forced visible by
+		// policy, so Hidden narrows toward true instead of being ANDed.
+		b.Hidden(grant.Hidden || *requested.Hidden)
+	}
+	if len(requested.CanPublishSources) > 0 {
+		sources := intersectSources(grant.CanPublishSources, requested.CanPublishSources)
+		if len(sources) == 0 {
+			// An empty source list would leave every source open, so an empty
+			// intersection denies publishing altogether.
+			b.CanPublish(false)
+		}
+		b.CanPublishSources(sources)
+	}
+}
+
+// intersectSources keeps only the sources present in both allowed and
+// requested. An empty allowed list means every source is allowed, so the
+// requested list passes through unchanged in that case.
+func intersectSources(allowed, requested []string) []string {
+	if len(allowed) == 0 {
+		return requested
+	}
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, s := range allowed {
+		allowedSet[s] = true
+	}
+
+	result := make([]string, 0, len(requested))
+	for _, s := range requested {
+		if allowedSet[s] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
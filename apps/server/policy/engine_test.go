@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+func boolPtr(v bool) *bool { return &v }
+
+func TestEngine_Evaluate_MostSpecificRoomPatternWins(t *testing.T) {
+	engine := NewEngine([]Rule{
+		{RoomPattern: "*", Grant: GrantSpec{CanPublish: boolPtr(true)}},
+		{RoomPattern: "lecture-*", Grant: GrantSpec{CanPublish: boolPtr(false)}},
+	})
+
+	builder, err := engine.Evaluate("lecture-hall", livekit.UserInfo{Identity: "alice"}, nil)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if builder.Grant().GetCanPublish() {
+		t.Error("expected the more specific lecture-* rule to win, denying publish")
+	}
+}
+
+func TestEngine_Evaluate_MostSpecificSelectorWins(t *testing.T) {
+	engine := NewEngine([]Rule{
+		{RoomPattern: "lecture-*", Grant: GrantSpec{CanPublish: boolPtr(false)}},
+		{
+			RoomPattern: "lecture-*",
+			Selector:    Selector{Groups: []string{"speakers"}},
+			Grant:       GrantSpec{CanPublish: boolPtr(true)},
+		},
+	})
+
+	speaker := livekit.UserInfo{Identity: "bob", Groups: []string{"speakers"}}
+	builder, err := engine.Evaluate("lecture-hall", speaker, nil)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !builder.Grant().GetCanPublish() {
+		t.Error("expected the group-scoped rule to win for a speaker, allowing publish")
+	}
+
+	listener := livekit.UserInfo{Identity: "carol"}
+	builder, err = engine.Evaluate("lecture-hall", listener, nil)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if builder.Grant().GetCanPublish() {
+		t.Error("expected the catch-all rule to apply to a non-speaker, denying publish")
+	}
+}
+
+func TestEngine_Evaluate_NoMatchingRule(t *testing.T) {
+	engine := NewEngine([]Rule{
+		{RoomPattern: "lecture-*", Grant: GrantSpec{CanPublish: boolPtr(false)}},
+	})
+
+	_, err := engine.Evaluate("standup", livekit.UserInfo{Identity: "alice"}, nil)
+	if err != ErrNoMatchingRule {
+		t.Errorf("expected ErrNoMatchingRule, got: %v", err)
+	}
+}
+
+func TestEngine_Evaluate_RequestedPermissionsNarrowButNeverBroaden(t *testing.T) {
+	engine := NewEngine([]Rule{
+		{RoomPattern: "lecture-*", Grant: GrantSpec{CanPublish: boolPtr(true), RoomAdmin: boolPtr(false)}},
+	})
+
+	// Narrowing: the client only wants to subscribe, not publish.
+	builder, err := engine.Evaluate("lecture-hall", livekit.UserInfo{Identity: "alice"}, &GrantSpec{CanPublish: boolPtr(false)})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if builder.Grant().GetCanPublish() {
+		t.Error("expected requested_permissions to narrow CanPublish to false")
+	}
+
+	// Attempting to broaden: the policy denies RoomAdmin, so requesting it
+	// must not grant it regardless of what the client asks for.
+	builder, err = engine.Evaluate("lecture-hall", livekit.UserInfo{Identity: "alice"}, &GrantSpec{RoomAdmin: boolPtr(true)})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if builder.Grant().RoomAdmin {
+		t.Error("expected requested_permissions to never broaden RoomAdmin beyond the matched rule")
+	}
+}
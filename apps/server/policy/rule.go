@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"path"
+
+	"github.com/babelgopher/server/livekit"
+)
+
+// Selector narrows a Rule to specific identities and/or groups. An empty
+// Selector matches every caller.
+type Selector struct {
+	Identities []string `yaml:"identities,omitempty" json:"identities,omitempty"`
+	Groups     []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+func (s Selector) isEmpty() bool {
+	return len(s.Identities) == 0 && len(s.Groups) == 0
+}
+
+func (s Selector) matches(user livekit.UserInfo) bool {
+	if s.isEmpty() {
+		return true
+	}
+	for _, identity := range s.Identities {
+		if identity == user.Identity {
+			return true
+		}
+	}
+	for _, group := range s.Groups {
+		for _, userGroup := range user.Groups {
+			if group == userGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// specificity scores how narrowly Selector targets a caller: an explicit
+// identity beats a group, which beats matching everyone.
+func (s Selector) specificity() int {
+	switch {
+	case len(s.Identities) > 0:
+		return 2
+	case len(s.Groups) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// GrantSpec mirrors the subset of auth.VideoGrant fields a policy rule, or
+// a client's requested_permissions, may set. A nil pointer means "no
+// preference"; CanPublishSources uses an empty slice for the same purpose.
+type GrantSpec struct {
+	RoomAdmin         *bool    `yaml:"room_admin,omitempty" json:"room_admin,omitempty"`
+	RoomCreate        *bool    `yaml:"room_create,omitempty" json:"room_create,omitempty"`
+	RoomList          *bool    `yaml:"room_list,omitempty" json:"room_list,omitempty"`
+	RoomRecord        *bool    `yaml:"room_record,omitempty" json:"room_record,omitempty"`
+	CanPublish        *bool    `yaml:"can_publish,omitempty" json:"can_publish,omitempty"`
+	CanSubscribe      *bool    `yaml:"can_subscribe,omitempty" json:"can_subscribe,omitempty"`
+	CanPublishData    *bool    `yaml:"can_publish_data,omitempty" json:"can_publish_data,omitempty"`
+	CanPublishSources []string `yaml:"can_publish_sources,omitempty" json:"can_publish_sources,omitempty"`
+	Hidden            *bool    `yaml:"hidden,omitempty" json:"hidden,omitempty"`
+	Recorder          *bool    `yaml:"recorder,omitempty" json:"recorder,omitempty"`
+}
+
+func (g GrantSpec) applyTo(b *livekit.GrantBuilder) {
+	if g.RoomAdmin != nil {
+		b.RoomAdmin(*g.RoomAdmin)
+	}
+	if g.RoomCreate != nil {
+		b.RoomCreate(*g.RoomCreate)
+	}
+	if g.RoomList != nil {
+		b.RoomList(*g.RoomList)
+	}
+	if g.RoomRecord != nil {
+		b.RoomRecord(*g.RoomRecord)
+	}
+	if g.CanPublish != nil {
+		b.CanPublish(*g.CanPublish)
+	}
+	if g.CanSubscribe != nil {
+		b.CanSubscribe(*g.CanSubscribe)
+	}
+	if g.CanPublishData != nil {
+		b.CanPublishData(*g.CanPublishData)
+	}
+	if len(g.CanPublishSources) > 0 {
+		b.CanPublishSources(g.CanPublishSources)
+	}
+	if g.Hidden != nil {
+		b.Hidden(*g.Hidden)
+	}
+	if g.Recorder != nil {
+		b.Recorder(*g.Recorder)
+	}
+}
+
+// Rule grants permissions to identities matching Selector in rooms whose
+// name matches RoomPattern, a path.Match glob (e.g. "lecture-*").
+type Rule struct {
+	RoomPattern string    `yaml:"room_pattern"`
+	Selector    Selector  `yaml:"selector"`
+	Grant       GrantSpec `yaml:"grant"`
+}
+
+func (r Rule) matches(roomName string, user livekit.UserInfo) bool {
+	ok, err := path.Match(r.RoomPattern, roomName)
+	if err != nil || !ok {
+		return false
+	}
+	return r.Selector.matches(user)
+}
+
+// specificity scores how narrowly Rule targets a room, by the length of
+// its pattern's fixed prefix before the first wildcard character; a
+// pattern with no wildcard at all scores highest. The Selector's own
+// specificity breaks ties between two rules with an equally specific
+// room pattern.
+func (r Rule) specificity() (patternScore, selectorScore int) {
+	if idx := indexOfWildcard(r.RoomPattern); idx >= 0 {
+		return idx, r.Selector.specificity()
+	}
+	return len(r.RoomPattern) + 1, r.Selector.specificity()
+}
+
+func indexOfWildcard(pattern string) int {
+	for i, c := range pattern {
+		if c == '*' || c == '?' || c == '[' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import "time"
+
+// Event names an occurrence that can trigger a webhook delivery.
+type Event string
+
+const (
+	EventTokenIssued Event = "token.issued"
+	EventTokenDenied Event = "token.denied"
+)
+
+// Endpoint is one configured webhook destination.
+type Endpoint struct {
+	URL    string
+	Secret string
+
+	// Timeout bounds a single delivery attempt; it defaults to 5s.
+	Timeout time.Duration
+	// MaxRetries is the number of retries after an initial failed attempt;
+	// it defaults to 3.
+	MaxRetries int
+	// BackoffBase is the delay before the first retry, doubled on each
+	// subsequent attempt; it defaults to 200ms.
+	BackoffBase time.Duration
+
+	// Events restricts delivery to the listed events. An empty slice
+	// subscribes to every event.
+	Events []Event
+}
+
+func (e Endpoint) wants(event Event) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, want := range e.Events {
+		if want == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Dispatcher delivers Payloads to configured endpoints through a bounded
+// worker pool, so a slow or unreachable webhook never blocks token issuance.
+type Dispatcher struct {
+	endpoints []Endpoint
+	jobs      chan job
+	client    *http.Client
+
+	failedDeliveries atomic.Uint64
+}
+
+type job struct {
+	endpoint Endpoint
+	payload  Payload
+}
+
+// NewDispatcher starts workerCount background workers draining a queue of
+// at most queueSize pending deliveries.
+func NewDispatcher(endpoints []Endpoint, workerCount, queueSize int) *Dispatcher {
+	d := &Dispatcher{
+		endpoints: endpoints,
+		jobs:      make(chan job, queueSize),
+		client:    &http.Client{},
+	}
+	for i := 0; i < workerCount; i++ {
+		go d.worker()
+	}
+	return d
+}
+
+// Deliver enqueues payload for every endpoint subscribed to its event. It
+// never blocks the caller beyond filling the bounded queue; a full queue
+// drops the delivery and counts it as failed.
+func (d *Dispatcher) Deliver(payload Payload) {
+	for _, ep := range d.endpoints {
+		if !ep.wants(payload.Event) {
+			continue
+		}
+		select {
+		case d.jobs <- job{endpoint: ep, payload: payload}:
+		default:
+			d.failedDeliveries.Add(1)
+			log.Printf("webhook: queue full, dropping delivery to %s", ep.URL)
+		}
+	}
+}
+
+// FailedDeliveries returns the running count of deliveries that could not
+// be completed, for exposure on /metrics.
+func (d *Dispatcher) FailedDeliveries() uint64 {
+	return d.failedDeliveries.Load()
+}
+
+func (d *Dispatcher) worker() {
+	for j := range d.jobs {
+		if err := d.deliverWithRetries(j); err != nil {
+			d.failedDeliveries.Add(1)
+			log.Printf("webhook: delivery to %s failed: %v", j.endpoint.URL, err)
+		}
+	}
+}
+
+func (d *Dispatcher) deliverWithRetries(j job) error {
+	body, err := json.Marshal(j.payload)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal payload: %w", err)
+	}
+
+	timeout := j.endpoint.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	maxRetries := j.endpoint.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 3
+	}
+	backoff := j.endpoint.BackoffBase
+	if backoff == 0 {
+		backoff = 200 * time.Millisecond
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff * time.Duration(uint(1)<<uint(attempt-1)))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		lastErr = d.send(ctx, j.endpoint, body)
+		cancel()
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func (d *Dispatcher) send(ctx context.Context, ep Endpoint, body []byte) error {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, sign(ep.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDispatcher_DeliverSignsRequest(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher([]Endpoint{{URL: srv.URL, Secret: "s3cret"}}, 1, 1)
+	d.Deliver(Payload{RequestID: "req-1", Event: EventTokenIssued, Identity: "alice", RoomName: "room-1"})
+
+	select {
+	case r := <-received:
+		if r.Header.Get(SignatureHeader) == "" {
+			t.Error("expected signature header to be set")
+		}
+		if r.Header.Get(TimestampHeader) == "" {
+			t.Error("expected timestamp header to be set")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+
+	if d.FailedDeliveries() != 0 {
+		t.Errorf("expected no failed deliveries, got %d", d.FailedDeliveries())
+	}
+}
+
+func TestDispatcher_SkipsUnsubscribedEvent(t *testing.T) {
+	received := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher([]Endpoint{{URL: srv.URL, Events: []Event{EventTokenDenied}}}, 1, 1)
+	d.Deliver(Payload{RequestID: "req-1", Event: EventTokenIssued})
+
+	select {
+	case <-received:
+		t.Fatal("endpoint not subscribed to token.issued should not have received a delivery")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_CountsFailedDeliveries(t *testing.T) {
+	d := NewDispatcher([]Endpoint{{URL: "http://127.0.0.1:0", MaxRetries: 0, Timeout: 100 * time.Millisecond}}, 1, 1)
+	d.Deliver(Payload{RequestID: "req-1", Event: EventTokenIssued})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for d.FailedDeliveries() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d.FailedDeliveries() == 0 {
+		t.Error("expected an unreachable endpoint to count as a failed delivery")
+	}
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// MetricsHandler serves the dispatcher's counters in Prometheus text
+// exposition format, for mounting at /metrics.
+func (d *Dispatcher) MetricsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintln(w, "# HELP babelgopher_webhook_failed_deliveries_total Total webhook deliveries that did not succeed.")
+		fmt.Fprintln(w, "# TYPE babelgopher_webhook_failed_deliveries_total counter")
+		fmt.Fprintf(w, "babelgopher_webhook_failed_deliveries_total %d\n", d.FailedDeliveries())
+	}
+}
@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/livekit/protocol/auth"
+)
+
+// Payload is the JSON body posted to every subscribed endpoint for a
+// token.issued or token.denied event.
+type Payload struct {
+	RequestID      string           `json:"request_id"`
+	Event          Event            `json:"event"`
+	Identity       string           `json:"identity"`
+	RoomName       string           `json:"room_name"`
+	Permissions    *auth.VideoGrant `json:"permissions,omitempty"`
+	IssuerAPIKeyID string           `json:"issuer_api_key_id"`
+	ClientIP       string           `json:"client_ip"`
+	Timestamp      time.Time        `json:"timestamp"`
+	// Reason is populated for token.denied, explaining why the request was rejected.
+	Reason string `json:"reason,omitempty"`
+}
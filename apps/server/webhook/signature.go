@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 of the delivery.
+	SignatureHeader = "X-BabelGopher-Signature"
+	// TimestampHeader carries the Unix timestamp the signature was computed
+	// over, letting receivers reject stale deliveries as replays.
+	TimestampHeader = "X-BabelGopher-Timestamp"
+)
+
+// sign computes the hex-encoded HMAC-SHA256 over "<timestamp>.<body>", so a
+// replayed body alone is not enough to reproduce a valid signature.
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}